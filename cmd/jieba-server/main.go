@@ -0,0 +1,67 @@
+// Command jieba-server runs gojieba's segmentation API over HTTP+JSON and
+// gRPC, backed by a pool of Jieba instances.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/tqy845/gojieba"
+	"github.com/tqy845/gojieba/server"
+	"github.com/tqy845/gojieba/server/pb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	httpAddr := flag.String("http", ":8080", "HTTP listen address")
+	grpcAddr := flag.String("grpc", ":9090", "gRPC listen address")
+	poolSize := flag.Int("pool-size", 0, "number of pooled Jieba instances (0 = runtime.NumCPU())")
+	flag.Parse()
+
+	pool := gojieba.NewJiebaPool(*poolSize, flag.Args()...)
+	defer pool.Close()
+
+	srv := server.NewServer(pool)
+
+	httpSrv := &http.Server{Addr: *httpAddr, Handler: srv.Handler()}
+	grpcSrv := grpc.NewServer()
+	pb.RegisterJiebaServiceServer(grpcSrv, srv)
+
+	lis, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		log.Fatalf("jieba-server: listen %s: %v", *grpcAddr, err)
+	}
+
+	go func() {
+		log.Printf("jieba-server: HTTP listening on %s", *httpAddr)
+		if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("jieba-server: HTTP server: %v", err)
+		}
+	}()
+	go func() {
+		log.Printf("jieba-server: gRPC listening on %s", *grpcAddr)
+		if err := grpcSrv.Serve(lis); err != nil {
+			log.Fatalf("jieba-server: gRPC server: %v", err)
+		}
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	<-sigCh
+	log.Print("jieba-server: shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpSrv.Shutdown(ctx); err != nil {
+		log.Printf("jieba-server: HTTP shutdown: %v", err)
+	}
+	grpcSrv.GracefulStop()
+	// pool.Close() runs via defer above, freeing every pooled instance.
+}
@@ -0,0 +1,160 @@
+package gojieba
+
+/*
+#include <stdlib.h>
+#include "jieba.h"
+*/
+import "C"
+import (
+	"sort"
+	"unsafe"
+)
+
+// DAG is cppjieba's MPSegment directed acyclic graph for a sentence: for
+// each character position i, Edges[i] lists the end positions reachable by
+// a dictionary word starting at i, and Probs[i] lists the corresponding
+// word-frequency log-probabilities (parallel to Edges[i]).
+type DAG struct {
+	Chars []rune
+	Edges [][]int
+	Probs [][]float64
+}
+
+// BuildDAG exposes cppjieba's internal MPSegment DAG construction for s, so
+// callers building custom rankers can work with the word lattice directly
+// instead of only the final Cut result.
+func (x *Jieba) BuildDAG(s string) *DAG {
+	x.checkFreed()
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var numNodes C.int
+	cNodes := C.BuildDAG(x.jieba, cstr, &numNodes)
+	defer C.FreeDAG(cNodes, numNodes)
+
+	n := int(numNodes)
+	nodes := unsafe.Slice(cNodes, n)
+
+	dag := &DAG{
+		Chars: []rune(s),
+		Edges: make([][]int, n),
+		Probs: make([][]float64, n),
+	}
+	for i, node := range nodes {
+		edges := unsafe.Slice(node.edges, int(node.numEdges))
+		dag.Edges[i] = make([]int, len(edges))
+		dag.Probs[i] = make([]float64, len(edges))
+		for j, e := range edges {
+			dag.Edges[i][j] = int(e.to)
+			dag.Probs[i][j] = float64(e.prob)
+		}
+	}
+	return dag
+}
+
+// ViterbiHMM runs cppjieba's HMM Viterbi decoder over s directly (the same
+// algorithm Cut falls back on for out-of-dictionary runs) and returns the
+// resulting word spans.
+func (x *Jieba) ViterbiHMM(s string) []Word {
+	x.checkFreed()
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+	words := C.ViterbiHMM(x.jieba, cstr)
+	defer C.free(unsafe.Pointer(words))
+	return convertWords(s, words)
+}
+
+// NBestCut returns the top-n segmentations of s ranked by total
+// log-probability over BuildDAG's lattice, computed with a beam search.
+// It mirrors the n-best segmentation feature found in other jieba ports,
+// where the DAG is reused directly for downstream ranking tasks.
+func (x *Jieba) NBestCut(s string, n int) [][]Word {
+	x.checkFreed()
+	if n <= 0 {
+		return nil
+	}
+	dag := x.BuildDAG(s)
+	paths := beamSearchDAG(dag, n)
+
+	res := make([][]Word, 0, len(paths))
+	for _, path := range paths {
+		res = append(res, dagPathToWords(s, dag, path))
+	}
+	return res
+}
+
+// dagBeamState is one partial path through the DAG during beam search.
+type dagBeamState struct {
+	pos     int
+	path    []int // character positions visited, in order, ending at pos
+	logProb float64
+}
+
+// beamHasUnfinished reports whether any entry in beam has not yet reached
+// numChars, i.e. whether beamSearchDAG still has paths left to expand. A
+// higher-ranked finished path must not stop expansion of a lower-ranked,
+// still-incomplete one.
+func beamHasUnfinished(beam []dagBeamState, numChars int) bool {
+	for _, st := range beam {
+		if st.pos < numChars {
+			return true
+		}
+	}
+	return false
+}
+
+// beamSearchDAG returns up to n distinct character-position paths from 0 to
+// len(dag.Chars) through dag, ranked by descending total log-probability.
+func beamSearchDAG(dag *DAG, n int) [][]int {
+	numChars := len(dag.Chars)
+	beam := []dagBeamState{{pos: 0, path: []int{0}}}
+
+	for beamHasUnfinished(beam, numChars) {
+		var next []dagBeamState
+		for _, st := range beam {
+			if st.pos >= numChars {
+				next = append(next, st)
+				continue
+			}
+			edges := dag.Edges[st.pos]
+			if len(edges) == 0 {
+				// No dictionary edge leaves st.pos; fall back to a
+				// single-character step so the path still reaches the end.
+				path := append(append([]int{}, st.path...), st.pos+1)
+				next = append(next, dagBeamState{pos: st.pos + 1, path: path, logProb: st.logProb})
+				continue
+			}
+			for i, to := range edges {
+				path := append(append([]int{}, st.path...), to)
+				next = append(next, dagBeamState{pos: to, path: path, logProb: st.logProb + dag.Probs[st.pos][i]})
+			}
+		}
+		sort.Slice(next, func(i, j int) bool { return next[i].logProb > next[j].logProb })
+		if len(next) > n {
+			next = next[:n]
+		}
+		beam = next
+	}
+
+	res := make([][]int, 0, len(beam))
+	for _, st := range beam {
+		res = append(res, st.path)
+	}
+	return res
+}
+
+// dagPathToWords converts a path of character positions (as produced by
+// beamSearchDAG) into the Word spans it denotes.
+func dagPathToWords(s string, dag *DAG, path []int) []Word {
+	runes := dag.Chars
+	words := make([]Word, 0, len(path)-1)
+	for i := 0; i+1 < len(path); i++ {
+		start, end := path[i], path[i+1]
+		words = append(words, Word{
+			Str:   string(runes[start:end]),
+			Start: start,
+			End:   end,
+		})
+	}
+	return words
+}
@@ -0,0 +1,73 @@
+package gojieba
+
+import "testing"
+
+// buildTestDAG builds a 4-rune DAG where position 0 has both a
+// high-probability edge spanning the whole string and a low-probability
+// single-character edge, and every other position only has a
+// single-character edge onward. This mirrors the shape that previously
+// tricked beamSearchDAG into stopping early once its top-ranked path
+// finished.
+func buildTestDAG() *DAG {
+	return &DAG{
+		Chars: []rune("abcd"),
+		Edges: [][]int{
+			{4, 1},
+			{2},
+			{3},
+			{4},
+		},
+		Probs: [][]float64{
+			{10, 1},
+			{1},
+			{1},
+			{1},
+		},
+	}
+}
+
+func TestBeamSearchDAGExpandsEveryBeamEntryToCompletion(t *testing.T) {
+	dag := buildTestDAG()
+	paths := beamSearchDAG(dag, 2)
+
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d: %v", len(paths), paths)
+	}
+	for _, p := range paths {
+		last := p[len(p)-1]
+		if last != len(dag.Chars) {
+			t.Errorf("path %v does not reach the end of the string (len=%d): ends at %d", p, len(dag.Chars), last)
+		}
+	}
+}
+
+func TestBeamHasUnfinished(t *testing.T) {
+	beam := []dagBeamState{{pos: 4}, {pos: 1}}
+	if !beamHasUnfinished(beam, 4) {
+		t.Errorf("expected an unfinished entry (pos=1 < numChars=4)")
+	}
+
+	done := []dagBeamState{{pos: 4}, {pos: 4}}
+	if beamHasUnfinished(done, 4) {
+		t.Errorf("expected no unfinished entries once every path reaches numChars")
+	}
+}
+
+func TestDagPathToWords(t *testing.T) {
+	dag := buildTestDAG()
+
+	words := dagPathToWords("abcd", dag, []int{0, 4})
+	if len(words) != 1 || words[0].Str != "abcd" || words[0].Start != 0 || words[0].End != 4 {
+		t.Errorf("dagPathToWords([0,4]) = %+v, want a single word spanning the whole string", words)
+	}
+
+	words = dagPathToWords("abcd", dag, []int{0, 1, 2, 3, 4})
+	if len(words) != 4 {
+		t.Fatalf("expected 4 single-character words, got %d: %+v", len(words), words)
+	}
+	for i, w := range words {
+		if w.Start != i || w.End != i+1 {
+			t.Errorf("word %d = %+v, want Start=%d End=%d", i, w, i, i+1)
+		}
+	}
+}
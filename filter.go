@@ -0,0 +1,206 @@
+package gojieba
+
+// Filter is a sensitive-word (dirty-word) matcher built as an Aho-Corasick
+// automaton over UTF-8 runes (not bytes), so multi-byte CJK sequences are
+// matched correctly. It is pure Go and has no dependency on cppjieba, so it
+// can be used standalone.
+type Filter struct {
+	root *filterNode
+}
+
+type filterNode struct {
+	children map[rune]*filterNode
+	fail     *filterNode
+	// end is true if the path from root to this node spells a filtered
+	// word.
+	end bool
+	// depth is the number of runes from root to this node, i.e. the
+	// length of the matched word when end is true.
+	depth int
+}
+
+func newFilterNode(depth int) *filterNode {
+	return &filterNode{children: make(map[rune]*filterNode), depth: depth}
+}
+
+// NewFilter builds a Filter that matches any of words.
+func NewFilter(words []string) *Filter {
+	root := newFilterNode(0)
+	for _, w := range words {
+		node := root
+		for _, r := range w {
+			child, ok := node.children[r]
+			if !ok {
+				child = newFilterNode(node.depth + 1)
+				node.children[r] = child
+			}
+			node = child
+		}
+		if len(w) > 0 {
+			node.end = true
+		}
+	}
+	buildFailLinks(root)
+	return &Filter{root: root}
+}
+
+// buildFailLinks assigns Aho-Corasick failure links via BFS over the trie.
+func buildFailLinks(root *filterNode) {
+	root.fail = root
+	queue := make([]*filterNode, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for r, child := range node.children {
+			fail := node.fail
+			for fail != root {
+				if next, ok := fail.children[r]; ok {
+					child.fail = next
+					break
+				}
+				fail = fail.fail
+			}
+			if child.fail == nil {
+				if next, ok := root.children[r]; ok && next != child {
+					child.fail = next
+				} else {
+					child.fail = root
+				}
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// step advances the automaton from node on rune r, following fail links as
+// needed, and returns the resulting node.
+func (node *filterNode) step(root *filterNode, r rune) *filterNode {
+	for node != root {
+		if next, ok := node.children[r]; ok {
+			return next
+		}
+		node = node.fail
+	}
+	if next, ok := root.children[r]; ok {
+		return next
+	}
+	return root
+}
+
+// matches returns true if n, or any node reachable by following fail
+// links from n, marks the end of a filtered word.
+func (n *filterNode) matches() bool {
+	for cur := n; cur != nil; cur = cur.fail {
+		if cur.end {
+			return true
+		}
+		if cur.fail == cur {
+			break
+		}
+	}
+	return false
+}
+
+// matchLens returns the length (in runes) of every filtered word ending at
+// n, by walking the whole fail chain, so a word that is a suffix of
+// another match (e.g. "he" inside "she") is still reported. The lengths
+// are returned longest-first; nil if none match.
+func (n *filterNode) matchLens() []int {
+	var lens []int
+	for cur := n; ; cur = cur.fail {
+		if cur.end {
+			lens = append(lens, cur.depth)
+		}
+		if cur.fail == cur {
+			break
+		}
+	}
+	return lens
+}
+
+// FindAll returns every occurrence of a filtered word in s, in order of
+// appearance, reusing the existing Word type (Start/End are rune offsets,
+// not bytes). Overlapping matches (one word a suffix of another) are all
+// reported.
+func (f *Filter) FindAll(s string) []Word {
+	runes := []rune(s)
+	node := f.root
+	var matches []Word
+	for i, r := range runes {
+		node = node.step(f.root, r)
+		for _, length := range node.matchLens() {
+			start := i + 1 - length
+			matches = append(matches, Word{
+				Str:   string(runes[start : i+1]),
+				Start: start,
+				End:   i + 1,
+			})
+		}
+	}
+	return matches
+}
+
+// Contains reports whether s contains any filtered word.
+func (f *Filter) Contains(s string) bool {
+	node := f.root
+	for _, r := range s {
+		node = node.step(f.root, r)
+		if node.matches() {
+			return true
+		}
+	}
+	return false
+}
+
+// Mask returns a copy of s with every rune covered by a filtered-word match
+// replaced by mask.
+func (f *Filter) Mask(s string, mask rune) string {
+	runes := []rune(s)
+	masked := make([]bool, len(runes))
+	for _, m := range f.FindAll(s) {
+		for i := m.Start; i < m.End; i++ {
+			masked[i] = true
+		}
+	}
+	out := make([]rune, len(runes))
+	for i, r := range runes {
+		if masked[i] {
+			out[i] = mask
+		} else {
+			out[i] = r
+		}
+	}
+	return string(out)
+}
+
+// CutAndFilter cuts s the same way Cut does, then drops any token whose
+// span overlaps a match in f.
+func (x *Jieba) CutAndFilter(s string, hmm bool, f *Filter) []string {
+	words := x.Tokenize(s, DefaultMode, hmm)
+	runes := []rune(s)
+	hit := make([]bool, len(runes))
+	for _, m := range f.FindAll(s) {
+		for i := m.Start; i < m.End; i++ {
+			hit[i] = true
+		}
+	}
+
+	res := make([]string, 0, len(words))
+	for _, w := range words {
+		overlaps := false
+		for i := w.Start; i < w.End && i < len(hit); i++ {
+			if hit[i] {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			res = append(res, w.Str)
+		}
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package gojieba
+
+import "testing"
+
+func TestFilterContains(t *testing.T) {
+	f := NewFilter([]string{"坏蛋", "笨蛋"})
+
+	if !f.Contains("他是一个大坏蛋") {
+		t.Errorf("Contains should find 坏蛋")
+	}
+	if f.Contains("他是一个好人") {
+		t.Errorf("Contains should not find anything in a clean sentence")
+	}
+}
+
+func TestFilterFindAll(t *testing.T) {
+	f := NewFilter([]string{"坏蛋", "世界"})
+
+	matches := f.FindAll("坏蛋你好世界坏蛋")
+	if len(matches) != 3 {
+		t.Fatalf("expected 3 matches, got %d: %+v", len(matches), matches)
+	}
+	for i, want := range []Word{
+		{Str: "坏蛋", Start: 0, End: 2},
+		{Str: "世界", Start: 4, End: 6},
+		{Str: "坏蛋", Start: 6, End: 8},
+	} {
+		if matches[i] != want {
+			t.Errorf("match %d = %+v, want %+v", i, matches[i], want)
+		}
+	}
+}
+
+func TestFilterFindAllOverlappingSuffix(t *testing.T) {
+	// "she" contains "he" as a suffix; the automaton should report both,
+	// ending at the same position (index 4 in "ushery").
+	f := NewFilter([]string{"he", "she"})
+	matches := f.FindAll("ushery")
+
+	want := []Word{
+		{Str: "she", Start: 1, End: 4},
+		{Str: "he", Start: 2, End: 4},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("FindAll(%q) = %+v, want %+v", "ushery", matches, want)
+	}
+	for i, w := range want {
+		if matches[i] != w {
+			t.Errorf("match %d = %+v, want %+v", i, matches[i], w)
+		}
+	}
+}
+
+func TestFilterMask(t *testing.T) {
+	f := NewFilter([]string{"坏蛋"})
+	got := f.Mask("他是坏蛋吗", '*')
+	want := "他是**吗"
+	if got != want {
+		t.Errorf("Mask() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterEmpty(t *testing.T) {
+	f := NewFilter(nil)
+	if f.Contains("随便什么内容") {
+		t.Errorf("an empty Filter should never match")
+	}
+	if got := f.FindAll("随便什么内容"); len(got) != 0 {
+		t.Errorf("an empty Filter should return no matches, got %+v", got)
+	}
+}
@@ -0,0 +1,107 @@
+package gojieba
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// NewJiebaFromFS builds a Jieba from dictionary files materialized out of
+// fsys, so single-binary deployments can ship their dictionaries with
+// //go:embed instead of shipping them on disk next to the executable.
+// paths has the same meaning as in NewJieba (dict, hmm, user dict, idf,
+// stop words), except each path is resolved against fsys rather than the
+// OS filesystem. getDictPaths' zero-arg defaults are absolute OS paths,
+// which are not valid fs.FS paths (fs.ValidPath rejects a leading "/"), so
+// any path NewJieba would otherwise default is instead normalized to its
+// base filename (e.g. "jieba.dict.utf8") and looked up at fsys's root;
+// pass explicit paths to NewJiebaFromFS to point at a different layout.
+// Unlike NewJieba, it returns an error instead of panicking when a
+// dictionary is missing, so callers can fall back to GetSharedInstance or
+// an on-disk NewJieba.
+func NewJiebaFromFS(fsys fs.FS, paths ...string) (*Jieba, error) {
+	dictpaths := getDictPaths(paths...)
+	for i, p := range dictpaths {
+		if !fs.ValidPath(p) {
+			dictpaths[i] = filepath.Base(p)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "gojieba-fs-*")
+	if err != nil {
+		return nil, fmt.Errorf("gojieba: create temp dir: %w", err)
+	}
+
+	localPaths := make([]string, len(dictpaths))
+	for i, p := range dictpaths {
+		f, err := fsys.Open(p)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("gojieba: open %q: %w", p, err)
+		}
+		localPaths[i], err = materializeDict(dir, filepath.Base(p), f)
+		f.Close()
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	jieba := NewJieba(localPaths...)
+	jieba.tempDir = dir
+	return jieba, nil
+}
+
+// NewJiebaFromReaders builds a Jieba by reading each dictionary from the
+// given io.Reader and materializing it into a temp directory before
+// invoking C.NewJieba; the temp directory is removed on Free. This lets
+// callers source dictionaries from anywhere (network, archive, generated
+// in-memory) rather than only from disk paths.
+func NewJiebaFromReaders(dict, hmm, userDict, idf, stopWords io.Reader) (*Jieba, error) {
+	dir, err := os.MkdirTemp("", "gojieba-readers-*")
+	if err != nil {
+		return nil, fmt.Errorf("gojieba: create temp dir: %w", err)
+	}
+
+	names := [...]string{"jieba.dict.utf8", "hmm_model.utf8", "user.dict.utf8", "idf.utf8", "stop_words.utf8"}
+	readers := [...]io.Reader{dict, hmm, userDict, idf, stopWords}
+
+	localPaths := make([]string, len(names))
+	for i, r := range readers {
+		localPaths[i], err = materializeDict(dir, names[i], r)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	jieba := NewJieba(localPaths...)
+	jieba.tempDir = dir
+	return jieba, nil
+}
+
+// MustNewJiebaFromFS is like NewJiebaFromFS but panics on error, for the
+// common case of loading a trusted //go:embed'd dictionary.
+func MustNewJiebaFromFS(fsys fs.FS, paths ...string) *Jieba {
+	jieba, err := NewJiebaFromFS(fsys, paths...)
+	if err != nil {
+		panic(err)
+	}
+	return jieba
+}
+
+// materializeDict copies r into dir/name and returns the resulting path.
+func materializeDict(dir, name string, r io.Reader) (string, error) {
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("gojieba: create %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return "", fmt.Errorf("gojieba: write %s: %w", path, err)
+	}
+	return path, nil
+}
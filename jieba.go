@@ -31,6 +31,9 @@ type Word struct {
 type Jieba struct {
 	jieba C.Jieba
 	freed int32
+	// tempDir, when non-empty, holds dictionary files materialized by
+	// NewJiebaFromFS/NewJiebaFromReaders and is removed on Free.
+	tempDir string
 }
 
 var (
@@ -56,14 +59,13 @@ func NewJieba(paths ...string) *Jieba {
 	defer C.free(unsafe.Pointer(ipath))
 	defer C.free(unsafe.Pointer(spath))
 	jieba := &Jieba{
-		C.NewJieba(
+		jieba: C.NewJieba(
 			dpath,
 			hpath,
 			upath,
 			ipath,
 			spath,
 		),
-		0,
 	}
 	// set finalizer to free the memory when the object is garbage collected
 	runtime.SetFinalizer(jieba, (*Jieba).Free)
@@ -111,6 +113,9 @@ func (x *Jieba) Free() {
 		x.jieba = nil
 		// 清除finalizer，避免重复释放
 		runtime.SetFinalizer(x, nil)
+		if x.tempDir != "" {
+			os.RemoveAll(x.tempDir)
+		}
 	}
 }
 
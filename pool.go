@@ -0,0 +1,157 @@
+package gojieba
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// JiebaPool is a fixed-size pool of *Jieba instances. Because every
+// Cut*/Tag/Tokenize call crosses cgo with locking inside cppjieba, a single
+// shared instance (see GetSharedInstance) serializes heavy concurrent
+// workloads; JiebaPool round-robins requests across N independent
+// instances instead.
+type JiebaPool struct {
+	instances []*Jieba
+	next      uint64
+	freed     int32
+}
+
+// NewJiebaPool preallocates a JiebaPool of size n, each backed by its own
+// *Jieba constructed from paths (see NewJieba). If n <= 0, runtime.NumCPU()
+// instances are created.
+func NewJiebaPool(n int, paths ...string) *JiebaPool {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	instances := make([]*Jieba, n)
+	for i := range instances {
+		instances[i] = NewJieba(paths...)
+	}
+	return &JiebaPool{instances: instances}
+}
+
+func (p *JiebaPool) checkFreed() {
+	if p.Freed() {
+		panic("JiebaPool has been freed")
+	}
+}
+
+// Freed reports whether Close has been called on the pool.
+func (p *JiebaPool) Freed() bool {
+	return atomic.LoadInt32(&p.freed) != 0
+}
+
+// pick returns the next instance in round-robin order.
+func (p *JiebaPool) pick() *Jieba {
+	p.checkFreed()
+	n := atomic.AddUint64(&p.next, 1)
+	return p.instances[n%uint64(len(p.instances))]
+}
+
+func (p *JiebaPool) Cut(s string, hmm bool) []string {
+	return p.pick().Cut(s, hmm)
+}
+
+func (p *JiebaPool) CutAll(s string) []string {
+	return p.pick().CutAll(s)
+}
+
+func (p *JiebaPool) CutForSearch(s string, hmm bool) []string {
+	return p.pick().CutForSearch(s, hmm)
+}
+
+func (p *JiebaPool) Tokenize(s string, mode TokenizeMode, hmm bool) []Word {
+	return p.pick().Tokenize(s, mode, hmm)
+}
+
+func (p *JiebaPool) Extract(s string, topk int) []string {
+	return p.pick().Extract(s, topk)
+}
+
+// Instances returns the pool's underlying *Jieba instances, e.g. so a
+// caller can broadcast AddWord/RemoveWord to every instance in the pool.
+func (p *JiebaPool) Instances() []*Jieba {
+	p.checkFreed()
+	return p.instances
+}
+
+// streamResult carries a CutStream result tagged with its input sequence
+// number so results can be reordered back into submission order.
+type streamResult struct {
+	seq    uint64
+	tokens []string
+}
+
+// CutStream fans cut requests from in out across the pool and emits results
+// on the returned channel in the same order they were received on in,
+// regardless of which pool instance finished first. The returned channel is
+// closed once in is drained (or ctx is done) and all in-flight work has been
+// emitted.
+func (p *JiebaPool) CutStream(ctx context.Context, in <-chan string, hmm bool) <-chan []string {
+	out := make(chan []string)
+	results := make(chan streamResult)
+
+	go func() {
+		defer close(results)
+		var seq uint64
+		var wg sync.WaitGroup
+		for {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			case s, ok := <-in:
+				if !ok {
+					wg.Wait()
+					return
+				}
+				mySeq := seq
+				seq++
+				wg.Add(1)
+				go func(seq uint64, s string) {
+					defer wg.Done()
+					tokens := p.Cut(s, hmm)
+					select {
+					case results <- streamResult{seq: seq, tokens: tokens}:
+					case <-ctx.Done():
+					}
+				}(mySeq, s)
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		pending := make(map[uint64][]string)
+		var nextSeq uint64
+		for r := range results {
+			pending[r.seq] = r.tokens
+			for {
+				tokens, ok := pending[nextSeq]
+				if !ok {
+					break
+				}
+				delete(pending, nextSeq)
+				select {
+				case out <- tokens:
+				case <-ctx.Done():
+					return
+				}
+				nextSeq++
+			}
+		}
+	}()
+
+	return out
+}
+
+// Close frees every instance in the pool. Further calls on the pool panic.
+func (p *JiebaPool) Close() {
+	if atomic.CompareAndSwapInt32(&p.freed, 0, 1) {
+		for _, j := range p.instances {
+			j.Free()
+		}
+	}
+}
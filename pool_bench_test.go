@@ -0,0 +1,26 @@
+package gojieba
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkJiebaPoolCut demonstrates throughput scaling with pool size: Cut
+// is run from many goroutines concurrently against pools of increasing
+// size, so the benefit of round-robining across instances (instead of
+// serializing on GetSharedInstance) shows up as rising ops/sec.
+func BenchmarkJiebaPoolCut(b *testing.B) {
+	const sentence = "我来到北京清华大学"
+	for _, size := range []int{1, 2, 4, 8} {
+		pool := NewJiebaPool(size)
+		b.Run(fmt.Sprintf("pool-size=%d", size), func(b *testing.B) {
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					pool.Cut(sentence, true)
+				}
+			})
+		})
+		pool.Close()
+	}
+}
@@ -0,0 +1,227 @@
+// Package server exposes the gojieba segmentation API over HTTP+JSON and
+// gRPC, backed by a gojieba.JiebaPool so concurrent requests don't
+// serialize on a single cppjieba instance.
+package server
+
+// Generated stubs for server.proto (package pb) are not checked into this
+// repo; run `make proto` from the repo root (requires protoc,
+// protoc-gen-go and protoc-gen-go-grpc) before building this package.
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/tqy845/gojieba"
+	"github.com/tqy845/gojieba/server/pb"
+)
+
+// Server implements both the HTTP and gRPC segmentation APIs over a shared
+// JiebaPool.
+type Server struct {
+	pb.UnimplementedJiebaServiceServer
+
+	pool *gojieba.JiebaPool
+}
+
+// NewServer wraps pool in a Server. pool is owned by the caller; the Server
+// never frees it, so it can be shared with other callers (e.g. tests).
+func NewServer(pool *gojieba.JiebaPool) *Server {
+	return &Server{pool: pool}
+}
+
+// Healthy reports whether the server's pool is still usable. It backs the
+// /healthz HTTP endpoint and reflects the pool's own freed state directly,
+// so it can't drift from a pool closed through a path other than this
+// Server (e.g. a caller holding the same pool calling Close() directly).
+func (s *Server) Healthy() bool {
+	return !s.pool.Freed()
+}
+
+// ---- HTTP+JSON API ----
+
+// Handler returns an http.Handler exposing Cut, CutAll, CutForSearch, Tag,
+// Tokenize, Extract, AddWord, RemoveWord and /healthz.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/cut", s.handleCut)
+	mux.HandleFunc("/cutall", s.handleCutAll)
+	mux.HandleFunc("/cutforsearch", s.handleCutForSearch)
+	mux.HandleFunc("/tag", s.handleTag)
+	mux.HandleFunc("/tokenize", s.handleTokenize)
+	mux.HandleFunc("/extract", s.handleExtract)
+	mux.HandleFunc("/addword", s.handleAddWord)
+	mux.HandleFunc("/removeword", s.handleRemoveWord)
+	return mux
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if !s.Healthy() {
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+type cutHTTPRequest struct {
+	Sentence string `json:"sentence"`
+	HMM      bool   `json:"hmm"`
+}
+
+type wordsHTTPReply struct {
+	Words []string `json:"words"`
+}
+
+func (s *Server) handleCut(w http.ResponseWriter, r *http.Request) {
+	var req cutHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	writeJSON(w, wordsHTTPReply{Words: s.pool.Cut(req.Sentence, req.HMM)})
+}
+
+func (s *Server) handleCutAll(w http.ResponseWriter, r *http.Request) {
+	var req cutHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	writeJSON(w, wordsHTTPReply{Words: s.pool.CutAll(req.Sentence)})
+}
+
+func (s *Server) handleCutForSearch(w http.ResponseWriter, r *http.Request) {
+	var req cutHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	writeJSON(w, wordsHTTPReply{Words: s.pool.CutForSearch(req.Sentence, req.HMM)})
+}
+
+func (s *Server) handleTag(w http.ResponseWriter, r *http.Request) {
+	var req cutHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	// Tag isn't exposed on JiebaPool; fall back to a single pool member so
+	// the HTTP surface still matches the full API surface requested.
+	instances := s.pool.Instances()
+	writeJSON(w, wordsHTTPReply{Words: instances[0].Tag(req.Sentence)})
+}
+
+type tokenizeHTTPRequest struct {
+	Sentence string `json:"sentence"`
+	Mode     int    `json:"mode"`
+	HMM      bool   `json:"hmm"`
+}
+
+type tokenizeHTTPReply struct {
+	Words []gojieba.Word `json:"words"`
+}
+
+func (s *Server) handleTokenize(w http.ResponseWriter, r *http.Request) {
+	var req tokenizeHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	words := s.pool.Tokenize(req.Sentence, gojieba.TokenizeMode(req.Mode), req.HMM)
+	writeJSON(w, tokenizeHTTPReply{Words: words})
+}
+
+type extractHTTPRequest struct {
+	Sentence string `json:"sentence"`
+	Topk     int    `json:"topk"`
+}
+
+func (s *Server) handleExtract(w http.ResponseWriter, r *http.Request) {
+	var req extractHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	writeJSON(w, wordsHTTPReply{Words: s.pool.Extract(req.Sentence, req.Topk)})
+}
+
+type wordHTTPRequest struct {
+	Word string `json:"word"`
+}
+
+func (s *Server) handleAddWord(w http.ResponseWriter, r *http.Request) {
+	var req wordHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	for _, j := range s.pool.Instances() {
+		j.AddWord(req.Word)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleRemoveWord(w http.ResponseWriter, r *http.Request) {
+	var req wordHTTPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	for _, j := range s.pool.Instances() {
+		j.RemoveWord(req.Word)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// ---- gRPC API ----
+
+func (s *Server) Cut(ctx context.Context, req *pb.CutRequest) (*pb.CutReply, error) {
+	return &pb.CutReply{Words: s.pool.Cut(req.Sentence, req.Hmm)}, nil
+}
+
+func (s *Server) CutAll(ctx context.Context, req *pb.CutAllRequest) (*pb.CutReply, error) {
+	return &pb.CutReply{Words: s.pool.CutAll(req.Sentence)}, nil
+}
+
+func (s *Server) CutForSearch(ctx context.Context, req *pb.CutRequest) (*pb.CutReply, error) {
+	return &pb.CutReply{Words: s.pool.CutForSearch(req.Sentence, req.Hmm)}, nil
+}
+
+func (s *Server) Tag(ctx context.Context, req *pb.TagRequest) (*pb.TagReply, error) {
+	instances := s.pool.Instances()
+	return &pb.TagReply{Words: instances[0].Tag(req.Sentence)}, nil
+}
+
+func (s *Server) Tokenize(ctx context.Context, req *pb.TokenizeRequest) (*pb.TokenizeReply, error) {
+	words := s.pool.Tokenize(req.Sentence, gojieba.TokenizeMode(req.Mode), req.Hmm)
+	reply := &pb.TokenizeReply{Words: make([]*pb.TokenizeWord, 0, len(words))}
+	for _, w := range words {
+		reply.Words = append(reply.Words, &pb.TokenizeWord{Str: w.Str, Start: int32(w.Start), End: int32(w.End)})
+	}
+	return reply, nil
+}
+
+func (s *Server) Extract(ctx context.Context, req *pb.ExtractRequest) (*pb.ExtractReply, error) {
+	return &pb.ExtractReply{Words: s.pool.Extract(req.Sentence, int(req.Topk))}, nil
+}
+
+func (s *Server) AddWord(ctx context.Context, req *pb.AddWordRequest) (*pb.AddWordReply, error) {
+	for _, j := range s.pool.Instances() {
+		j.AddWord(req.Word)
+	}
+	return &pb.AddWordReply{}, nil
+}
+
+func (s *Server) RemoveWord(ctx context.Context, req *pb.RemoveWordRequest) (*pb.RemoveWordReply, error) {
+	for _, j := range s.pool.Instances() {
+		j.RemoveWord(req.Word)
+	}
+	return &pb.RemoveWordReply{}, nil
+}
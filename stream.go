@@ -0,0 +1,168 @@
+package gojieba
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	// streamChunkSize is the amount read from the underlying io.Reader on
+	// each iteration of CutReader.
+	streamChunkSize = 64 * 1024
+	// streamMaxWordLen is how many trailing bytes of the buffer a forced
+	// split (see splitStreamBuffer) carries over into the next buffer
+	// instead of tokenizing them now. No bytes are tokenized twice, so a
+	// word that spans the forced split point is cut in two.
+	streamMaxWordLen = 64
+	// streamMaxBuffer is the point at which CutReader gives up waiting for
+	// sentence-ending punctuation and force-splits the buffer, so pathological
+	// input with no punctuation at all doesn't grow the buffer unbounded.
+	streamMaxBuffer = 8 * streamChunkSize
+)
+
+// streamSentenceEnders are the rune boundaries CutReader prefers to split
+// chunks on, so a cut chunk never ends mid-sentence where possible.
+var streamSentenceEnders = []rune{'。', '！', '？', '\n'}
+
+// CutReader reads r in ~64KB chunks and calls Cut on each chunk, streaming
+// resulting tokens to emit. It is meant for multi-MB documents where
+// reading the whole input into memory (as Cut does via C.CString) is
+// wasteful. Chunks are only split on UTF-8 rune boundaries and, where
+// possible, on a trailing sentence-ending punctuation mark (。！？ or \n) so
+// that a word spanning what would otherwise be a chunk boundary is instead
+// kept whole and re-tokenized together with the following chunk. When no
+// such mark appears within streamMaxBuffer bytes, the buffer is force-split
+// at a rune boundary instead (see splitStreamBuffer). emit is
+// called once per token in order; if it returns an error, CutReader stops
+// and returns that error.
+func (x *Jieba) CutReader(r io.Reader, hmm bool, emit func(word string) error) error {
+	br := bufio.NewReaderSize(r, streamChunkSize)
+	var carry []byte
+
+	for {
+		chunk := make([]byte, streamChunkSize)
+		n, readErr := br.Read(chunk)
+		chunk = chunk[:n]
+
+		buf := append(carry, chunk...)
+		carry = nil
+
+		atEOF := readErr == io.EOF
+		if !atEOF && readErr != nil {
+			return readErr
+		}
+
+		for {
+			var piece []byte
+			if atEOF {
+				piece, buf = buf, nil
+			} else {
+				var ok bool
+				piece, buf, ok = splitStreamBuffer(buf)
+				if !ok {
+					carry = buf
+					break
+				}
+			}
+			if len(piece) == 0 {
+				break
+			}
+			for _, word := range x.Cut(string(piece), hmm) {
+				if err := emit(word); err != nil {
+					return err
+				}
+			}
+			if atEOF {
+				break
+			}
+		}
+
+		if atEOF {
+			return nil
+		}
+	}
+}
+
+// splitStreamBuffer decides how much of buf is safe to tokenize now.
+// It returns (piece, rest, true) when it found a safe split point, or
+// (nil, buf, false) when buf should simply accumulate more data first.
+func splitStreamBuffer(buf []byte) (piece, rest []byte, ok bool) {
+	validLen := validUTF8Prefix(buf)
+
+	if idx := lastSentenceEnderEnd(buf[:validLen]); idx > 0 {
+		return buf[:idx], buf[idx:], true
+	}
+
+	if len(buf) < streamMaxBuffer {
+		// Not enough signal yet to split on punctuation; wait for more
+		// input unless we've already buffered enough to need a forced cut.
+		return nil, buf, false
+	}
+
+	// No sentence boundary in sight and the buffer is large: force a split
+	// streamMaxWordLen bytes before the end of the valid data, moved back to
+	// a rune start, and carry the bytes after it into the next buffer. The
+	// split point is not moved to a word boundary, so a word that straddles
+	// it is tokenized as two pieces.
+	cut := validLen - streamMaxWordLen
+	if cut <= 0 {
+		return nil, buf, false
+	}
+	for cut > 0 && !utf8.RuneStart(buf[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		return nil, buf, false
+	}
+	return buf[:cut], buf[cut:], true
+}
+
+// validUTF8Prefix returns the length of the longest prefix of buf that
+// contains no partial rune at the end.
+func validUTF8Prefix(buf []byte) int {
+	n := len(buf)
+	for i := 1; i <= utf8.UTFMax && i <= n; i++ {
+		if utf8.RuneStart(buf[n-i]) {
+			if utf8.FullRune(buf[n-i:]) {
+				return n
+			}
+			return n - i
+		}
+	}
+	return n
+}
+
+// lastSentenceEnderEnd returns the byte offset just past the last
+// sentence-ending rune in buf, or 0 if none is present.
+func lastSentenceEnderEnd(buf []byte) int {
+	best := 0
+	for i := 0; i < len(buf); {
+		r, size := utf8.DecodeRune(buf[i:])
+		for _, ender := range streamSentenceEnders {
+			if r == ender {
+				best = i + size
+				break
+			}
+		}
+		i += size
+	}
+	return best
+}
+
+// CutStreamWriter is a CLI-friendly wrapper over CutReader: it cuts r and
+// writes each resulting token to w followed by sep.
+func (x *Jieba) CutStreamWriter(r io.Reader, w io.Writer, sep string, hmm bool) error {
+	bw := bufio.NewWriter(w)
+	err := x.CutReader(r, hmm, func(word string) error {
+		if _, err := bw.WriteString(word); err != nil {
+			return err
+		}
+		_, err := bw.WriteString(sep)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
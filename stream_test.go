@@ -0,0 +1,79 @@
+package gojieba
+
+import "testing"
+
+func TestValidUTF8Prefix(t *testing.T) {
+	full := []byte("北京")
+	if got := validUTF8Prefix(full); got != len(full) {
+		t.Errorf("validUTF8Prefix(%q) = %d, want %d", full, got, len(full))
+	}
+
+	// Truncate the last rune (京, 3 bytes) by one byte so it's incomplete.
+	truncated := full[:len(full)-1]
+	want := len("北")
+	if got := validUTF8Prefix(truncated); got != want {
+		t.Errorf("validUTF8Prefix(truncated) = %d, want %d", got, want)
+	}
+}
+
+func TestLastSentenceEnderEnd(t *testing.T) {
+	s := []byte("你好。世界")
+	idx := lastSentenceEnderEnd(s)
+	want := len("你好。")
+	if idx != want {
+		t.Errorf("lastSentenceEnderEnd(%q) = %d, want %d", s, idx, want)
+	}
+
+	if got := lastSentenceEnderEnd([]byte("没有标点")); got != 0 {
+		t.Errorf("lastSentenceEnderEnd with no punctuation = %d, want 0", got)
+	}
+}
+
+func TestSplitStreamBufferOnPunctuation(t *testing.T) {
+	buf := []byte("第一句。第二句还没完")
+	piece, rest, ok := splitStreamBuffer(buf)
+	if !ok {
+		t.Fatalf("expected a split to be found")
+	}
+	if string(piece) != "第一句。" {
+		t.Errorf("piece = %q, want %q", piece, "第一句。")
+	}
+	if string(rest) != "第二句还没完" {
+		t.Errorf("rest = %q, want %q", rest, "第二句还没完")
+	}
+}
+
+func TestSplitStreamBufferWaitsForMoreData(t *testing.T) {
+	buf := []byte("还没有句末标点")
+	_, rest, ok := splitStreamBuffer(buf)
+	if ok {
+		t.Fatalf("expected splitStreamBuffer to wait for more data, got a split")
+	}
+	if string(rest) != string(buf) {
+		t.Errorf("rest = %q, want unchanged buffer %q", rest, buf)
+	}
+}
+
+func TestSplitStreamBufferForcesSplitWithoutPunctuation(t *testing.T) {
+	// Build a buffer with no sentence-ending punctuation but large enough
+	// to cross streamMaxBuffer, forcing a split.
+	var buf []byte
+	for len(buf) < streamMaxBuffer+1 {
+		buf = append(buf, []byte("字")...)
+	}
+
+	piece, rest, ok := splitStreamBuffer(buf)
+	if !ok {
+		t.Fatalf("expected a forced split once the buffer exceeds streamMaxBuffer")
+	}
+	if !validRuneBoundary(piece) || !validRuneBoundary(rest) {
+		t.Errorf("forced split must not cut a rune in half: len(piece)=%d len(rest)=%d", len(piece), len(rest))
+	}
+	if len(piece)+len(rest) != len(buf) {
+		t.Errorf("split must account for every byte: got %d, want %d", len(piece)+len(rest), len(buf))
+	}
+}
+
+func validRuneBoundary(b []byte) bool {
+	return validUTF8Prefix(b) == len(b)
+}
@@ -0,0 +1,179 @@
+package gojieba
+
+import "sort"
+
+// textRankWindow is the default size of the sliding co-occurrence window
+// used when building the TextRank graph.
+const textRankWindow = 5
+
+const (
+	textRankDamping   = 0.85
+	textRankMaxIter   = 200
+	textRankConverged = 1e-5
+)
+
+// textRankAllowedPOS returns true for the POS tags TextRank should consider
+// as candidate vertices: nouns, verbs and adjectives.
+func textRankAllowedPOS(pos string) bool {
+	if pos == "" {
+		return false
+	}
+	switch pos[0] {
+	case 'n', 'v', 'a':
+		return true
+	default:
+		return false
+	}
+}
+
+// textRankCandidates runs Tag over s and returns the ordered list of
+// candidate words (nouns/verbs/adjectives), deduplicating nothing so that
+// co-occurrence windows still reflect the original token order.
+func (x *Jieba) textRankCandidates(s string) []string {
+	tagged := x.Tag(s)
+	candidates := make([]string, 0, len(tagged))
+	for _, wt := range tagged {
+		word, pos := splitWordTag(wt)
+		if word == "" || !textRankAllowedPOS(pos) {
+			continue
+		}
+		candidates = append(candidates, word)
+	}
+	return candidates
+}
+
+// splitWordTag splits a "word/pos" entry as produced by Tag.
+func splitWordTag(wt string) (word, pos string) {
+	idx := -1
+	for i := len(wt) - 1; i >= 0; i-- {
+		if wt[i] == '/' {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return wt, ""
+	}
+	return wt[:idx], wt[idx+1:]
+}
+
+// buildTextRankGraph builds an undirected weighted co-occurrence graph over
+// candidates: an edge is added between any two words that appear within
+// textRankWindow tokens of each other, weighted by the number of times the
+// pair co-occurs.
+func buildTextRankGraph(candidates []string) map[string]map[string]float64 {
+	graph := make(map[string]map[string]float64)
+	addEdge := func(a, b string) {
+		if a == b {
+			return
+		}
+		if graph[a] == nil {
+			graph[a] = make(map[string]float64)
+		}
+		if graph[b] == nil {
+			graph[b] = make(map[string]float64)
+		}
+		graph[a][b]++
+		graph[b][a]++
+	}
+	for i, w := range candidates {
+		if graph[w] == nil {
+			graph[w] = make(map[string]float64)
+		}
+		for j := i + 1; j < len(candidates) && j < i+textRankWindow; j++ {
+			addEdge(w, candidates[j])
+		}
+	}
+	return graph
+}
+
+// rankTextRankGraph runs the weighted PageRank recurrence over graph until
+// the scores converge (L1 delta < textRankConverged) or textRankMaxIter
+// iterations elapse, returning the unnormalized score of each vertex.
+func rankTextRankGraph(graph map[string]map[string]float64) map[string]float64 {
+	scores := make(map[string]float64, len(graph))
+	for v := range graph {
+		scores[v] = 1.0
+	}
+	outWeight := make(map[string]float64, len(graph))
+	for v, edges := range graph {
+		var sum float64
+		for _, w := range edges {
+			sum += w
+		}
+		outWeight[v] = sum
+	}
+
+	for iter := 0; iter < textRankMaxIter; iter++ {
+		next := make(map[string]float64, len(graph))
+		var delta float64
+		for v, edges := range graph {
+			sum := 0.0
+			for u, w := range edges {
+				if outWeight[u] == 0 {
+					continue
+				}
+				sum += (w / outWeight[u]) * scores[u]
+			}
+			s := (1 - textRankDamping) + textRankDamping*sum
+			next[v] = s
+			if d := s - scores[v]; d > 0 {
+				delta += d
+			} else {
+				delta -= d
+			}
+		}
+		scores = next
+		if delta < textRankConverged {
+			break
+		}
+	}
+	return scores
+}
+
+// ExtractTextRank extracts the topk keywords from s using the TextRank
+// algorithm: candidate words are selected from nouns/verbs/adjectives (via
+// Tag), linked into a co-occurrence graph over a sliding window, and ranked
+// with weighted PageRank. It is an alternative to the TF-IDF based Extract.
+func (x *Jieba) ExtractTextRank(s string, topk int) []string {
+	ww := x.ExtractTextRankWithWeight(s, topk)
+	res := make([]string, 0, len(ww))
+	for _, w := range ww {
+		res = append(res, w.Word)
+	}
+	return res
+}
+
+// ExtractTextRankWithWeight is like ExtractTextRank but also returns each
+// keyword's normalized TextRank score in [0, 1].
+func (x *Jieba) ExtractTextRankWithWeight(s string, topk int) []WordWeight {
+	candidates := x.textRankCandidates(s)
+	graph := buildTextRankGraph(candidates)
+	scores := rankTextRankGraph(graph)
+
+	result := make([]WordWeight, 0, len(scores))
+	var max float64
+	for w, sc := range scores {
+		result = append(result, WordWeight{Word: w, Weight: sc})
+		if sc > max {
+			max = sc
+		}
+	}
+	if max > 0 {
+		for i := range result {
+			result[i].Weight /= max
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Weight != result[j].Weight {
+			return result[i].Weight > result[j].Weight
+		}
+		return result[i].Word < result[j].Word
+	})
+
+	if topk >= 0 && topk < len(result) {
+		result = result[:topk]
+	}
+	return result
+}
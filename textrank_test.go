@@ -0,0 +1,65 @@
+package gojieba
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplitWordTag(t *testing.T) {
+	cases := []struct {
+		in       string
+		wantWord string
+		wantPOS  string
+	}{
+		{"北京/ns", "北京", "ns"},
+		{"清华大学/nt", "清华大学", "nt"},
+		{"noslash", "noslash", ""},
+	}
+	for _, c := range cases {
+		word, pos := splitWordTag(c.in)
+		if word != c.wantWord || pos != c.wantPOS {
+			t.Errorf("splitWordTag(%q) = (%q, %q), want (%q, %q)", c.in, word, pos, c.wantWord, c.wantPOS)
+		}
+	}
+}
+
+func TestTextRankAllowedPOS(t *testing.T) {
+	allowed := []string{"n", "ns", "nt", "v", "vn", "a", "ad"}
+	disallowed := []string{"", "x", "u", "p", "m", "y"}
+	for _, pos := range allowed {
+		if !textRankAllowedPOS(pos) {
+			t.Errorf("textRankAllowedPOS(%q) = false, want true", pos)
+		}
+	}
+	for _, pos := range disallowed {
+		if textRankAllowedPOS(pos) {
+			t.Errorf("textRankAllowedPOS(%q) = true, want false", pos)
+		}
+	}
+}
+
+func TestBuildTextRankGraphSymmetric(t *testing.T) {
+	graph := buildTextRankGraph([]string{"a", "b", "c"})
+	if graph["a"]["b"] != graph["b"]["a"] {
+		t.Fatalf("graph should be undirected: a-b=%v b-a=%v", graph["a"]["b"], graph["b"]["a"])
+	}
+	if graph["a"]["c"] == 0 {
+		t.Fatalf("expected an edge between a and c within the default window")
+	}
+	if _, ok := graph["a"]["a"]; ok {
+		t.Fatalf("graph should not contain self-edges")
+	}
+}
+
+func TestRankTextRankGraphConverges(t *testing.T) {
+	graph := buildTextRankGraph([]string{"a", "b", "a", "b", "c"})
+	scores := rankTextRankGraph(graph)
+	for v, s := range scores {
+		if math.IsNaN(s) || math.IsInf(s, 0) {
+			t.Fatalf("score for %q did not converge to a finite value: %v", v, s)
+		}
+	}
+	if len(scores) != 3 {
+		t.Fatalf("expected 3 scored vertices, got %d", len(scores))
+	}
+}